@@ -0,0 +1,339 @@
+package hlru
+
+import (
+	"errors"
+	"sync"
+)
+
+/*
+ARC[K, V] implements the Adaptive Replacement Cache. It keeps four
+lists over a fixed capacity c: T1 (recent, seen once) and T2 (frequent,
+seen at least twice) hold values; B1 and B2 are ghost lists holding only
+the keys recently evicted from T1 and T2. A tuning parameter p in
+[0, c] is the target size for T1 and adapts on every ghost hit - a hit
+in B1 grows p (favouring recency), a hit in B2 shrinks it (favouring
+frequency).
+*/
+
+type ARC[K comparable, V any] struct {
+	size int
+	p    int
+
+	t1, t2 *simpleLRU[K, V]
+	b1, b2 *simpleLRU[K, struct{}]
+
+	onEvictedCB func(key K, value V)
+	lock        sync.RWMutex
+}
+
+// Returns a new ARC cache instance
+func NewARC[K comparable, V any](size int) (*ARC[K, V], error) {
+
+	return NewARCWithEvict[K, V](size, nil)
+
+}
+
+func NewARCWithEvict[K comparable, V any](size int, onEvict func(key K, value V)) (*ARC[K, V], error) {
+
+	if size <= 0 {
+		return nil, errors.New("Size must be a postive int")
+	}
+
+	lru := &ARC[K, V]{
+		size:        size,
+		p:           0,
+		onEvictedCB: onEvict,
+		t1:          newSimpleLRU[K, V](size),
+		t2:          newSimpleLRU[K, V](size),
+		b1:          newSimpleLRU[K, struct{}](size),
+		b2:          newSimpleLRU[K, struct{}](size),
+	}
+
+	return lru, nil
+
+}
+
+// replace evicts the LRU entry of T1 or T2 into the matching ghost
+// list, per the standard ARC rule. b2Hit indicates the caller is
+// handling a B2 hit, which nudges the choice towards evicting from T1.
+func (lru *ARC[K, V]) replace(b2Hit bool) {
+
+	t1Len := lru.t1.len()
+
+	if t1Len > 0 && (t1Len > lru.p || (t1Len == lru.p && b2Hit)) {
+		key, value, ok := lru.t1.removeOldest()
+		if ok {
+			lru.b1.add(key, struct{}{})
+			if lru.onEvictedCB != nil {
+				lru.onEvictedCB(key, value)
+			}
+		}
+		return
+	}
+
+	key, value, ok := lru.t2.removeOldest()
+	if ok {
+		lru.b2.add(key, struct{}{})
+		if lru.onEvictedCB != nil {
+			lru.onEvictedCB(key, value)
+		}
+	}
+
+}
+
+// Set a value and update the cache
+func (lru *ARC[K, V]) Set(key K, value V) {
+
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	if lru.t1.contains(key) {
+		lru.t1.remove(key)
+		lru.t2.add(key, value)
+		return
+	}
+
+	if lru.t2.contains(key) {
+		lru.t2.add(key, value)
+		return
+	}
+
+	if lru.b1.contains(key) {
+		b1Len, b2Len := lru.b1.len(), lru.b2.len()
+		delta := 1
+		if b2Len > b1Len {
+			delta = b2Len / b1Len
+		}
+		if lru.p+delta >= lru.size {
+			lru.p = lru.size
+		} else {
+			lru.p += delta
+		}
+
+		if lru.t1.len()+lru.t2.len() >= lru.size {
+			lru.replace(false)
+		}
+
+		lru.b1.remove(key)
+		lru.t2.add(key, value)
+		return
+	}
+
+	if lru.b2.contains(key) {
+		b1Len, b2Len := lru.b1.len(), lru.b2.len()
+		delta := 1
+		if b1Len > b2Len {
+			delta = b1Len / b2Len
+		}
+		if delta >= lru.p {
+			lru.p = 0
+		} else {
+			lru.p -= delta
+		}
+
+		if lru.t1.len()+lru.t2.len() >= lru.size {
+			lru.replace(true)
+		}
+
+		lru.b2.remove(key)
+		lru.t2.add(key, value)
+		return
+	}
+
+	// Brand new key.
+	if lru.t1.len()+lru.b1.len() == lru.size {
+		if lru.t1.len() < lru.size {
+			lru.b1.removeOldest()
+			lru.replace(false)
+		} else {
+			evictedKey, evictedValue, ok := lru.t1.removeOldest()
+			if ok && lru.onEvictedCB != nil {
+				lru.onEvictedCB(evictedKey, evictedValue)
+			}
+		}
+	} else {
+		total := lru.t1.len() + lru.b1.len() + lru.t2.len() + lru.b2.len()
+		if total >= lru.size {
+			if total == 2*lru.size {
+				lru.b2.removeOldest()
+			}
+			lru.replace(false)
+		}
+	}
+
+	lru.t1.add(key, value)
+
+}
+
+// Get a value and update the cache
+func (lru *ARC[K, V]) Get(key K) (V, bool) {
+
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	if value, found := lru.t1.peek(key); found {
+		lru.t1.remove(key)
+		lru.t2.add(key, value)
+		return value, true
+	}
+
+	if value, found := lru.t2.get(key); found {
+		return value, true
+	}
+
+	var zero V
+	return zero, false
+
+}
+
+// Peek the value of a key without updating the cache
+func (lru *ARC[K, V]) Peek(key K) (V, bool) {
+
+	lru.lock.RLock()
+	defer lru.lock.RUnlock()
+
+	if value, found := lru.t1.peek(key); found {
+		return value, true
+	}
+
+	if value, found := lru.t2.peek(key); found {
+		return value, true
+	}
+
+	var zero V
+	return zero, false
+
+}
+
+// Checks if a key exists in cache
+func (lru *ARC[K, V]) Has(key K) bool {
+
+	lru.lock.RLock()
+	defer lru.lock.RUnlock()
+
+	return lru.t1.contains(key) || lru.t2.contains(key)
+
+}
+
+// Removes a key from the cache
+func (lru *ARC[K, V]) Remove(key K) bool {
+
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	if value, found := lru.t1.remove(key); found {
+		if lru.onEvictedCB != nil {
+			lru.onEvictedCB(key, value)
+		}
+		return true
+	}
+
+	if value, found := lru.t2.remove(key); found {
+		if lru.onEvictedCB != nil {
+			lru.onEvictedCB(key, value)
+		}
+		return true
+	}
+
+	if _, found := lru.b1.remove(key); found {
+		return true
+	}
+
+	if _, found := lru.b2.remove(key); found {
+		return true
+	}
+
+	return false
+
+}
+
+// Returns the number of items in the cache.
+func (lru *ARC[K, V]) Len() int {
+
+	lru.lock.RLock()
+	defer lru.lock.RUnlock()
+
+	return lru.t1.len() + lru.t2.len()
+
+}
+
+// Clears all entries.
+func (lru *ARC[K, V]) Clear() {
+
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	if lru.onEvictedCB != nil {
+		for _, key := range lru.t1.keys() {
+			value, _ := lru.t1.peek(key)
+			lru.onEvictedCB(key, value)
+		}
+		for _, key := range lru.t2.keys() {
+			value, _ := lru.t2.peek(key)
+			lru.onEvictedCB(key, value)
+		}
+	}
+
+	lru.t1.purge()
+	lru.t2.purge()
+	lru.b1.purge()
+	lru.b2.purge()
+	lru.p = 0
+
+}
+
+func (lru *ARC[K, V]) Keys() []K {
+
+	lru.lock.RLock()
+	defer lru.lock.RUnlock()
+
+	keys := make([]K, 0, lru.t1.len()+lru.t2.len())
+	keys = append(keys, lru.t1.keys()...)
+	keys = append(keys, lru.t2.keys()...)
+
+	return keys
+
+}
+
+func (lru *ARC[K, V]) Vals() []V {
+
+	lru.lock.RLock()
+	defer lru.lock.RUnlock()
+
+	vals := make([]V, 0, lru.t1.len()+lru.t2.len())
+	vals = append(vals, lru.t1.vals()...)
+	vals = append(vals, lru.t2.vals()...)
+
+	return vals
+
+}
+
+// Resizes cache, returning number of items deleted
+func (lru *ARC[K, V]) Resize(newSize int) (int, error) {
+
+	if newSize <= 0 {
+		return 0, errors.New("Size must be a postive int")
+	}
+
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	lru.size = newSize
+	if lru.p > newSize {
+		lru.p = newSize
+	}
+
+	removed := 0
+	for lru.t1.len()+lru.t2.len() > lru.size {
+		lru.replace(false)
+		removed++
+	}
+
+	lru.t1.resize(newSize)
+	lru.t2.resize(newSize)
+	lru.b1.resize(newSize)
+	lru.b2.resize(newSize)
+
+	return removed, nil
+
+}
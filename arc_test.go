@@ -0,0 +1,127 @@
+package hlru
+
+import (
+	"testing"
+)
+
+func Test_ARC(t *testing.T) {
+
+	lru, err := NewARC[int, int](100)
+
+	if err != nil {
+		t.Fatalf("Error in creating ARC: %v", err)
+	}
+
+	for i := 0; i < 150; i++ {
+		lru.Set(i, i)
+	}
+
+	if lru.Len() != 100 {
+		t.Fatalf("Error in ARC length: %v", lru.Len())
+	}
+
+	lru.Clear()
+
+	if lru.Len() != 0 {
+		t.Fatalf("Error in ARC Clear(): %v", lru.Len())
+	}
+
+	lru.Set(1, 1)
+	lru.Set(2, 1)
+
+	keys := lru.Keys()
+
+	for i := 0; i < len(keys); i++ {
+		// Both keys start in t1; unlike 2Q, ARC promotes straight to t2
+		// on the first Get.
+		_, ok := lru.Get(keys[i])
+		if !ok {
+			t.Fatalf("Error: %v", keys[i])
+		}
+		if !lru.t2.contains(keys[i]) {
+			t.Fatalf("Error: %v not promoted to t2 by Get", keys[i])
+		}
+	}
+
+	if len(keys) != lru.Len() {
+		t.Fatalf("Error: %v", keys)
+	}
+
+}
+
+func Test_ARC_FavoursFrequentKeys(t *testing.T) {
+
+	lru, _ := NewARC[int, int](8)
+
+	// Key 0 is read on every round, so it should sit in T2 (frequent)
+	// and keep surviving the flood of one-off keys below.
+	for round := 0; round < 20; round++ {
+		lru.Set(0, 0)
+		lru.Get(0)
+
+		for i := 1; i <= 8; i++ {
+			lru.Set(round*8+i, round*8+i)
+		}
+	}
+
+	if !lru.Has(0) {
+		t.Fatalf("Error: expected frequently accessed key to survive eviction")
+	}
+
+}
+
+func Test_ARC_Remove_Resize(t *testing.T) {
+
+	lru, _ := NewARC[int, int](2)
+
+	lru.Set(1, 1)
+	lru.Set(2, 2)
+
+	ok := lru.Remove(2)
+
+	if !ok {
+		t.Fatalf("Error in Remove()")
+	}
+
+	if lru.Has(2) != false {
+		t.Fatalf("Error in Remove()")
+	}
+
+	lru.Clear()
+
+	lru.Set(1, 1)
+	lru.Set(2, 2)
+
+	evicted, _ := lru.Resize(1)
+
+	if evicted != 1 {
+		t.Fatalf("Error in Down Sizing")
+	}
+
+	if lru.Len() != 1 {
+		t.Fatalf("Error in ARC length: %v", lru.Len())
+	}
+
+}
+
+func Test_ARC_Resize_Grow(t *testing.T) {
+
+	lru, _ := NewARC[int, int](4)
+
+	for i := 0; i < 4; i++ {
+		lru.Set(i, i)
+	}
+
+	if _, err := lru.Resize(100); err != nil {
+		t.Fatalf("Error in Up Sizing: %v", err)
+	}
+
+	for i := 4; i < 100; i++ {
+		lru.Set(i, i)
+	}
+
+	if lru.Len() != 100 {
+		t.Fatalf("Error in ARC length after growing: %v", lru.Len())
+	}
+
+}
@@ -36,6 +36,38 @@ func BenchmarkHLRU_Rand(b *testing.B) {
 
 }
 
+// BenchmarkHLRU_Interface_Allocs and BenchmarkCache_Generic_Allocs compare
+// the interface{} based HashLRU against the generic Cache[K, V] on the
+// same access pattern, to show the allocations saved by avoiding boxing.
+
+func BenchmarkHLRU_Interface_Allocs(b *testing.B) {
+
+	lru, _ := NewHLRU(8192)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		lru.Set(i, i)
+		lru.Get(i)
+	}
+
+}
+
+func BenchmarkCache_Generic_Allocs(b *testing.B) {
+
+	lru, _ := NewCache[int, int](8192)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		lru.Set(i, i)
+		lru.Get(i)
+	}
+
+}
+
 func BenchmarkHLRU_Freq(b *testing.B) {
 
 	lru, _ := NewHLRU(8192)
@@ -0,0 +1,39 @@
+package hlru
+
+import (
+	"testing"
+	"time"
+)
+
+// Test_Evict_Reentrant proves that onEvictedCB, which now runs with no
+// lock held, can safely call back into the same cache without
+// deadlocking.
+func Test_Evict_Reentrant(t *testing.T) {
+
+	var lru *HashLRU
+	reentered := make(chan bool, 2)
+
+	onEvict := func(key, value interface{}) {
+		lru.Set("reentered", true)
+		lru.Get("reentered")
+		reentered <- true
+	}
+
+	lru, err := NewWithEvict(2, onEvict)
+
+	if err != nil {
+		t.Fatalf("Error in creating LRU: %v", err)
+	}
+
+	lru.Set(1, 1)
+	lru.Set(2, 2)
+	lru.Set(3, 3) // fills both maps, triggers bulk eviction on next update
+	lru.Set(4, 4)
+
+	select {
+	case <-reentered:
+	case <-time.After(time.Second):
+		t.Fatalf("Error: onEvict callback deadlocked when calling back into the cache")
+	}
+
+}
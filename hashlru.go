@@ -4,26 +4,54 @@ import (
 	"errors"
 	"sync"
 	"math"
+	"time"
 )
 
 /*
-The HashLRU algorithm maintains two separate maps 
+The HashLRU algorithm maintains two separate maps
 and bulk eviction happens only after both the maps fill up
 
-Hence the the callBack function is triggered in bulk and 
+Hence the the callBack function is triggered in bulk and
 is not an accurate measure. Use NewWithEvict() with caution.
+
+onEvictedCB is always invoked with lru.lock released - evicted
+keys/values are buffered during the locked section and drained
+afterwards, so it is safe for a callback to call back into Set, Get,
+Remove, Clear or Resize on the same cache without deadlocking.
 */
 
+// cacheEntry wraps a stored value with an optional expiration deadline.
+// expiresAt is a UnixNano timestamp; zero means the entry never expires.
+type cacheEntry struct {
+	value 		interface{}
+	expiresAt	int64
+}
+
+func (e cacheEntry) expired() bool {
+	return e.expiresAt != 0 && time.Now().UnixNano() > e.expiresAt
+}
+
+// DefaultEvictedBufferSize is the initial capacity of the buffers
+// HashLRU uses to collect keys/values evicted during a locked section,
+// before invoking onEvictedCB once the lock has been released.
+const DefaultEvictedBufferSize = 16
+
+// HashLRU is the interface{}-based cache. Prefer Cache[K, V] in new code
+// for a type-safe API that avoids boxing and type assertions.
 type HashLRU struct {
 	maxSize  					int
 	size     					int
-	oldCache, newCache 			map[interface{}]interface{}
+	oldCache, newCache 			map[interface{}]cacheEntry
 	onEvictedCB					func (key, value interface{})
+	defaultTTL					time.Duration
+	stopJanitor					chan struct{}
+	evictedKeys, evictedVals	[]interface{}
 	lock     					sync.RWMutex
 }
 
 type KVPair struct {
 	key, value			interface{}
+	expiresAt			int64
 }
 
 // Returns a new hashlru instance
@@ -43,76 +71,228 @@ func NewWithEvict(maxSize int, onEvict func(key, value interface{})) (*HashLRU,
 		maxSize:  maxSize,
 		size:     0,
 		onEvictedCB: onEvict,
-		oldCache: make(map[interface{}]interface{}),
-		newCache: make(map[interface{}]interface{}),
+		oldCache: make(map[interface{}]cacheEntry),
+		newCache: make(map[interface{}]cacheEntry),
+		evictedKeys: make([]interface{}, 0, DefaultEvictedBufferSize),
+		evictedVals: make([]interface{}, 0, DefaultEvictedBufferSize),
+	}
+
+	return lru, nil
+
+}
+
+/*
+NewHLRUWithTTL returns a HashLRU whose entries expire after defaultTTL
+unless overridden per-key via SetWithTTL. It also starts a background
+janitor goroutine that sweeps both maps for expired entries at the same
+interval as defaultTTL, passing anything it removes to onEvictedCB,
+which this constructor leaves nil. Call
+Close() to stop the janitor once the cache is no longer needed.
+*/
+func NewHLRUWithTTL(maxSize int, defaultTTL time.Duration) (*HashLRU, error) {
+
+	lru, err := NewWithEvict(maxSize, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	lru.defaultTTL = defaultTTL
+
+	if defaultTTL > 0 {
+		lru.startJanitor(defaultTTL)
 	}
 
 	return lru, nil
 
 }
 
+func (lru *HashLRU) startJanitor(interval time.Duration) {
+
+	lru.stopJanitor = make(chan struct{})
+	stop := lru.stopJanitor
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				lru.sweep()
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+}
+
+// sweep walks both maps and evicts anything past its deadline.
+func (lru *HashLRU) sweep() {
+
+	lru.lock.Lock()
+
+	for key, entry := range lru.oldCache {
+		if entry.expired() {
+			delete(lru.oldCache, key)
+			lru.queueEvicted(key, entry.value)
+		}
+	}
+
+	for key, entry := range lru.newCache {
+		if entry.expired() {
+			delete(lru.newCache, key)
+			lru.queueEvicted(key, entry.value)
+		}
+	}
+
+	lru.lock.Unlock()
+	lru.drainEvicted()
+
+}
+
+// Close stops the background janitor goroutine, if one was started via
+// NewHLRUWithTTL. It is a no-op otherwise and safe to call more than once.
+func (lru *HashLRU) Close() {
+
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	if lru.stopJanitor != nil {
+		close(lru.stopJanitor)
+		lru.stopJanitor = nil
+	}
+
+}
+
 /*
 update(key, value interface{}) is used internally in Get() and Set()
 to impose least recently by pushing all recently accessed keys to the newCache
-and the oldCache acts as a back up dump once newCache fills up. 
+and the oldCache acts as a back up dump once newCache fills up.
 
-Bulk eviction takes place from the oldCache
+Bulk eviction takes place from the oldCache. Callers must hold lru.lock
+and call drainEvicted() once they've released it.
 */
 
-func (lru *HashLRU) update(key, value interface{}) {
+func (lru *HashLRU) update(key interface{}, entry cacheEntry) {
 
-	lru.newCache[key] = value
+	lru.newCache[key] = entry
 	lru.size++
 
 	if lru.size >= lru.maxSize {
 		lru.size = 0
 
-		if lru.onEvictedCB != nil {
-			for key, value := range lru.oldCache {
-				lru.onEvictedCB(key, value)
-			}
+		for key, entry := range lru.oldCache {
+			lru.queueEvicted(key, entry.value)
 		}
-		
-		lru.oldCache = make(map[interface{}]interface{})
-		for key, value := range lru.newCache {
-			lru.oldCache[key] = value
+
+		lru.oldCache = make(map[interface{}]cacheEntry)
+		for key, entry := range lru.newCache {
+			lru.oldCache[key] = entry
 		}
 
-		lru.newCache = make(map[interface{}]interface{})
+		lru.newCache = make(map[interface{}]cacheEntry)
 	}
 
 }
 
-// Set a value and update the cache
+/*
+queueEvicted records a key/value pair evicted while lru.lock is held, so
+it can be handed to onEvictedCB later with no lock held. This is what
+lets onEvictedCB safely call back into Set/Get/Remove without deadlocking -
+it never runs while lru.lock is taken.
+*/
+func (lru *HashLRU) queueEvicted(key, value interface{}) {
+
+	if lru.onEvictedCB == nil {
+		return
+	}
+
+	lru.evictedKeys = append(lru.evictedKeys, key)
+	lru.evictedVals = append(lru.evictedVals, value)
+
+}
+
+// drainEvicted invokes onEvictedCB for everything queueEvicted collected
+// since the last drain. Must be called with lru.lock NOT held.
+func (lru *HashLRU) drainEvicted() {
+
+	if lru.onEvictedCB == nil {
+		return
+	}
+
+	lru.lock.Lock()
+	keys, vals := lru.evictedKeys, lru.evictedVals
+	lru.evictedKeys = make([]interface{}, 0, DefaultEvictedBufferSize)
+	lru.evictedVals = make([]interface{}, 0, DefaultEvictedBufferSize)
+	lru.lock.Unlock()
+
+	for i := range keys {
+		lru.onEvictedCB(keys[i], vals[i])
+	}
+
+}
+
+// Set a value and update the cache, using the cache's defaultTTL (none,
+// if the cache was created with NewHLRU/NewWithEvict).
 func (lru *HashLRU) Set(key, value interface{}) {
 
+	lru.SetWithTTL(key, value, lru.defaultTTL)
+
+}
+
+// SetWithTTL is like Set but expires the entry after ttl, overriding the
+// cache's defaultTTL. A ttl <= 0 means the entry never expires.
+func (lru *HashLRU) SetWithTTL(key, value interface{}, ttl time.Duration) {
+
 	lru.lock.Lock()
 
+	entry := cacheEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl).UnixNano()
+	}
+
 	if _, found := lru.newCache[key]; found {
-		lru.newCache[key] = value
+		lru.newCache[key] = entry
 	} else {
-		lru.update(key, value)
+		lru.update(key, entry)
 	}
 
 	lru.lock.Unlock()
+	lru.drainEvicted()
 
 }
 
-// Get a value and update the cache
+// Get a value and update the cache. An entry past its deadline is
+// treated as absent and deleted.
 func (lru *HashLRU) Get(key interface{}) (interface{}, bool) {
 
 	lru.lock.Lock()
 
-	if value, found := lru.newCache[key]; found {
+	if entry, found := lru.newCache[key]; found {
+		if entry.expired() {
+			delete(lru.newCache, key)
+			lru.queueEvicted(key, entry.value)
+			lru.lock.Unlock()
+			lru.drainEvicted()
+			return nil, false
+		}
 		lru.lock.Unlock()
-		return value, found
+		return entry.value, found
 	}
 
-	if value, found := lru.oldCache[key]; found {
+	if entry, found := lru.oldCache[key]; found {
 		delete(lru.oldCache, key)
-		lru.update(key, value)
+		if entry.expired() {
+			lru.queueEvicted(key, entry.value)
+			lru.lock.Unlock()
+			lru.drainEvicted()
+			return nil, false
+		}
+		lru.update(key, entry)
 		lru.lock.Unlock()
-		return value, found
+		lru.drainEvicted()
+		return entry.value, found
 	}
 
 	lru.lock.Unlock()
@@ -120,19 +300,49 @@ func (lru *HashLRU) Get(key interface{}) (interface{}, bool) {
 
 }
 
-// Peek the value of a key without updating the cache
+// GetWithExpiration returns a value along with its expiration deadline
+// (as UnixNano; zero if it never expires), without deleting it even if
+// it is already past its deadline - so callers can decide whether to
+// serve a stale value while they refresh it.
+func (lru *HashLRU) GetWithExpiration(key interface{}) (interface{}, int64, bool) {
+
+	lru.lock.RLock()
+
+	if entry, found := lru.newCache[key]; found {
+		lru.lock.RUnlock()
+		return entry.value, entry.expiresAt, true
+	}
+
+	if entry, found := lru.oldCache[key]; found {
+		lru.lock.RUnlock()
+		return entry.value, entry.expiresAt, true
+	}
+
+	lru.lock.RUnlock()
+	return nil, 0, false
+
+}
+
+// Peek the value of a key without updating the cache. An entry past its
+// deadline is treated as absent.
 func (lru *HashLRU) Peek(key interface{}) (interface{}, bool) {
 
 	lru.lock.RLock()
 
-	if value, found := lru.newCache[key]; found {
+	if entry, found := lru.newCache[key]; found {
 		lru.lock.RUnlock()
-		return value, found
+		if entry.expired() {
+			return nil, false
+		}
+		return entry.value, found
 	}
 
-	if value, found := lru.oldCache[key]; found {
+	if entry, found := lru.oldCache[key]; found {
 		lru.lock.RUnlock()
-		return value, found
+		if entry.expired() {
+			return nil, false
+		}
+		return entry.value, found
 	}
 
 	lru.lock.RUnlock()
@@ -140,17 +350,22 @@ func (lru *HashLRU) Peek(key interface{}) (interface{}, bool) {
 
 }
 
-// Checks if a key exists in cache
+// Checks if a key exists in cache. An entry past its deadline is
+// treated as absent.
 func (lru *HashLRU) Has(key interface{}) bool {
 
 	lru.lock.RLock()
 
-	_, cacheNew := lru.newCache[key]
-	_, cacheOld := lru.oldCache[key]
+	entryNew, cacheNew := lru.newCache[key]
+	entryOld, cacheOld := lru.oldCache[key]
 
 	lru.lock.RUnlock()
 
-	return cacheNew || cacheOld
+	if cacheNew && !entryNew.expired() {
+		return true
+	}
+
+	return cacheOld && !entryOld.expired()
 
 }
 
@@ -159,22 +374,20 @@ func (lru *HashLRU) Remove(key interface{}) bool {
 
 	lru.lock.Lock()
 
-	if val, found := lru.newCache[key]; found {
+	if entry, found := lru.newCache[key]; found {
 		delete(lru.newCache, key)
 		lru.size--
-		if lru.onEvictedCB != nil {
-			lru.onEvictedCB(key, val)
-		}
+		lru.queueEvicted(key, entry.value)
 		lru.lock.Unlock()
+		lru.drainEvicted()
 		return true
 	}
 
-	if val, found := lru.oldCache[key]; found {
+	if entry, found := lru.oldCache[key]; found {
 		delete(lru.oldCache, key)
-		if lru.onEvictedCB != nil {
-			lru.onEvictedCB(key, val)
-		}
+		lru.queueEvicted(key, entry.value)
 		lru.lock.Unlock()
+		lru.drainEvicted()
 		return true
 	}
 
@@ -188,7 +401,7 @@ func (lru *HashLRU) Remove(key interface{}) bool {
 func (lru *HashLRU) Len() int {
 
 	lru.lock.RLock()
-	
+
 	if lru.size == 0 {
 		lru.lock.RUnlock()
 		return len(lru.oldCache)
@@ -210,22 +423,21 @@ func (lru *HashLRU) Len() int {
 // Clears all entries.
 func (lru *HashLRU) Clear() {
 
-	lru.lock.Lock() 
+	lru.lock.Lock()
 
-	if lru.onEvictedCB != nil {
-		for key, value := range lru.oldCache {
-			lru.onEvictedCB(key, value)
-		}
-		for key, value := range lru.newCache {
-			lru.onEvictedCB(key, value)
-		}
+	for key, entry := range lru.oldCache {
+		lru.queueEvicted(key, entry.value)
+	}
+	for key, entry := range lru.newCache {
+		lru.queueEvicted(key, entry.value)
 	}
 
-	lru.oldCache = make(map[interface{}]interface{})
-	lru.newCache = make(map[interface{}]interface{})
+	lru.oldCache = make(map[interface{}]cacheEntry)
+	lru.newCache = make(map[interface{}]cacheEntry)
 	lru.size = 0
 
 	lru.lock.Unlock()
+	lru.drainEvicted()
 
 }
 
@@ -245,7 +457,7 @@ func (lru* HashLRU) Keys() []interface{} {
 	for key, _ := range lru.newCache {
 		tempKeys = append(tempKeys, key)
 	}
-	
+
 	lru.lock.RUnlock()
 	return tempKeys
 
@@ -257,16 +469,16 @@ func (lru *HashLRU) Vals() []interface{} {
 
 	tempVals := make([]interface{}, 0)
 
-	for key, value := range lru.oldCache {
+	for key, entry := range lru.oldCache {
 		if _, found := lru.newCache[key]; !found {
-			tempVals = append(tempVals, value)
+			tempVals = append(tempVals, entry.value)
 		}
 	}
 
-	for _, value := range lru.newCache {
-		tempVals = append(tempVals, value)
+	for _, entry := range lru.newCache {
+		tempVals = append(tempVals, entry.value)
 	}
-	
+
 	lru.lock.RUnlock()
 	return tempVals
 
@@ -278,23 +490,25 @@ func (lru *HashLRU) all() []*KVPair {
 
 	allPairs := []*KVPair{}
 
-	for key, value := range lru.oldCache {
+	for key, entry := range lru.oldCache {
 		if _, found := lru.newCache[key]; !found {
 
 			kvPair := new(KVPair)
 			kvPair.key = key
-			kvPair.value = value
+			kvPair.value = entry.value
+			kvPair.expiresAt = entry.expiresAt
 			allPairs = append(allPairs, kvPair)
 		}
 	}
 
-	for key, value := range lru.newCache {
+	for key, entry := range lru.newCache {
 		kvPair := new(KVPair)
 		kvPair.key = key
-		kvPair.value = value
+		kvPair.value = entry.value
+		kvPair.expiresAt = entry.expiresAt
 		allPairs = append(allPairs, kvPair)
 	}
-	
+
 	lru.lock.RUnlock()
 	return allPairs
 
@@ -314,11 +528,11 @@ func (lru *HashLRU) Resize(newSize int) (int, error) {
 
 		lru.lock.Lock()
 
-		for key, value := range lru.oldCache {
-			lru.newCache[key] = value
+		for key, entry := range lru.oldCache {
+			lru.newCache[key] = entry
 		}
 
-		lru.oldCache = make(map[interface{}]interface{})
+		lru.oldCache = make(map[interface{}]cacheEntry)
 		lru.size = totalItems
 		lru.maxSize = newSize
 		lru.lock.Unlock()
@@ -331,26 +545,25 @@ func (lru *HashLRU) Resize(newSize int) (int, error) {
 
 		lru.lock.Lock()
 
-		lru.oldCache = make(map[interface{}]interface{})
-		lru.newCache = make(map[interface{}]interface{})
+		lru.oldCache = make(map[interface{}]cacheEntry)
+		lru.newCache = make(map[interface{}]cacheEntry)
 		lru.size = 0
 		lru.maxSize = newSize
 
 		var i = 0
 
 		for i < removeCount {
-			if lru.onEvictedCB != nil {
-				lru.onEvictedCB(allPairs[i].key, allPairs[i].value)
-			}
+			lru.queueEvicted(allPairs[i].key, allPairs[i].value)
 			i++
 		}
 
 		for i < len(allPairs) {
-			lru.oldCache[allPairs[i].key] = allPairs[i].value
+			lru.oldCache[allPairs[i].key] = cacheEntry{value: allPairs[i].value, expiresAt: allPairs[i].expiresAt}
 			i++
 		}
 
 		lru.lock.Unlock()
+		lru.drainEvicted()
 
 		return removeCount, nil
 
@@ -0,0 +1,347 @@
+package hlru
+
+import (
+	"errors"
+	"math"
+	"sync"
+)
+
+/*
+Cache[K, V] is the generic, type-safe counterpart of HashLRU.
+
+It implements the exact same two-map rotation algorithm as HashLRU but
+avoids the interface{} boxing and type assertions that come with it,
+mirroring the route hashicorp/golang-lru took when it moved to v2.
+New code should prefer Cache[K, V] over HashLRU.
+*/
+
+type Cache[K comparable, V any] struct {
+	maxSize            int
+	size               int
+	oldCache, newCache map[K]V
+	onEvictedCB        func(key K, value V)
+	lock               sync.RWMutex
+}
+
+type pair[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Returns a new generic cache instance
+func NewCache[K comparable, V any](maxSize int) (*Cache[K, V], error) {
+
+	return NewCacheWithEvict[K, V](maxSize, nil)
+
+}
+
+func NewCacheWithEvict[K comparable, V any](maxSize int, onEvict func(key K, value V)) (*Cache[K, V], error) {
+
+	if maxSize <= 0 {
+		return nil, errors.New("Size must be a postive int")
+	}
+
+	lru := &Cache[K, V]{
+		maxSize:     maxSize,
+		size:        0,
+		onEvictedCB: onEvict,
+		oldCache:    make(map[K]V),
+		newCache:    make(map[K]V),
+	}
+
+	return lru, nil
+
+}
+
+func (lru *Cache[K, V]) update(key K, value V) {
+
+	lru.newCache[key] = value
+	lru.size++
+
+	if lru.size >= lru.maxSize {
+		lru.size = 0
+
+		if lru.onEvictedCB != nil {
+			for key, value := range lru.oldCache {
+				lru.onEvictedCB(key, value)
+			}
+		}
+
+		lru.oldCache = make(map[K]V)
+		for key, value := range lru.newCache {
+			lru.oldCache[key] = value
+		}
+
+		lru.newCache = make(map[K]V)
+	}
+
+}
+
+// Set a value and update the cache
+func (lru *Cache[K, V]) Set(key K, value V) {
+
+	lru.lock.Lock()
+
+	if _, found := lru.newCache[key]; found {
+		lru.newCache[key] = value
+	} else {
+		lru.update(key, value)
+	}
+
+	lru.lock.Unlock()
+
+}
+
+// Get a value and update the cache
+func (lru *Cache[K, V]) Get(key K) (V, bool) {
+
+	lru.lock.Lock()
+
+	if value, found := lru.newCache[key]; found {
+		lru.lock.Unlock()
+		return value, found
+	}
+
+	if value, found := lru.oldCache[key]; found {
+		delete(lru.oldCache, key)
+		lru.update(key, value)
+		lru.lock.Unlock()
+		return value, found
+	}
+
+	lru.lock.Unlock()
+	var zero V
+	return zero, false
+
+}
+
+// Peek the value of a key without updating the cache
+func (lru *Cache[K, V]) Peek(key K) (V, bool) {
+
+	lru.lock.RLock()
+
+	if value, found := lru.newCache[key]; found {
+		lru.lock.RUnlock()
+		return value, found
+	}
+
+	if value, found := lru.oldCache[key]; found {
+		lru.lock.RUnlock()
+		return value, found
+	}
+
+	lru.lock.RUnlock()
+	var zero V
+	return zero, false
+
+}
+
+// Checks if a key exists in cache
+func (lru *Cache[K, V]) Has(key K) bool {
+
+	lru.lock.RLock()
+
+	_, cacheNew := lru.newCache[key]
+	_, cacheOld := lru.oldCache[key]
+
+	lru.lock.RUnlock()
+
+	return cacheNew || cacheOld
+
+}
+
+// Removes a key from the cache
+func (lru *Cache[K, V]) Remove(key K) bool {
+
+	lru.lock.Lock()
+
+	if val, found := lru.newCache[key]; found {
+		delete(lru.newCache, key)
+		lru.size--
+		if lru.onEvictedCB != nil {
+			lru.onEvictedCB(key, val)
+		}
+		lru.lock.Unlock()
+		return true
+	}
+
+	if val, found := lru.oldCache[key]; found {
+		delete(lru.oldCache, key)
+		if lru.onEvictedCB != nil {
+			lru.onEvictedCB(key, val)
+		}
+		lru.lock.Unlock()
+		return true
+	}
+
+	lru.lock.Unlock()
+
+	return false
+
+}
+
+// Returns the number of items in the cache.
+func (lru *Cache[K, V]) Len() int {
+
+	lru.lock.RLock()
+
+	if lru.size == 0 {
+		lru.lock.RUnlock()
+		return len(lru.oldCache)
+	}
+
+	oldCacheSize := 0
+
+	for key := range lru.oldCache {
+		if _, found := lru.newCache[key]; !found {
+			oldCacheSize++
+		}
+	}
+
+	lru.lock.RUnlock()
+	return int(math.Min(float64(lru.size+oldCacheSize), float64(lru.maxSize)))
+
+}
+
+// Clears all entries.
+func (lru *Cache[K, V]) Clear() {
+
+	lru.lock.Lock()
+
+	if lru.onEvictedCB != nil {
+		for key, value := range lru.oldCache {
+			lru.onEvictedCB(key, value)
+		}
+		for key, value := range lru.newCache {
+			lru.onEvictedCB(key, value)
+		}
+	}
+
+	lru.oldCache = make(map[K]V)
+	lru.newCache = make(map[K]V)
+	lru.size = 0
+
+	lru.lock.Unlock()
+
+}
+
+func (lru *Cache[K, V]) Keys() []K {
+
+	lru.lock.RLock()
+
+	tempKeys := make([]K, 0)
+
+	for key := range lru.oldCache {
+		if _, found := lru.newCache[key]; !found {
+			tempKeys = append(tempKeys, key)
+		}
+	}
+
+	for key := range lru.newCache {
+		tempKeys = append(tempKeys, key)
+	}
+
+	lru.lock.RUnlock()
+	return tempKeys
+
+}
+
+func (lru *Cache[K, V]) Vals() []V {
+
+	lru.lock.RLock()
+
+	tempVals := make([]V, 0)
+
+	for key, value := range lru.oldCache {
+		if _, found := lru.newCache[key]; !found {
+			tempVals = append(tempVals, value)
+		}
+	}
+
+	for _, value := range lru.newCache {
+		tempVals = append(tempVals, value)
+	}
+
+	lru.lock.RUnlock()
+	return tempVals
+
+}
+
+func (lru *Cache[K, V]) all() []*pair[K, V] {
+
+	lru.lock.RLock()
+
+	allPairs := []*pair[K, V]{}
+
+	for key, value := range lru.oldCache {
+		if _, found := lru.newCache[key]; !found {
+			allPairs = append(allPairs, &pair[K, V]{key: key, value: value})
+		}
+	}
+
+	for key, value := range lru.newCache {
+		allPairs = append(allPairs, &pair[K, V]{key: key, value: value})
+	}
+
+	lru.lock.RUnlock()
+	return allPairs
+
+}
+
+// Resizes cache, returning number of items deleted
+func (lru *Cache[K, V]) Resize(newSize int) (int, error) {
+
+	if newSize <= 0 {
+		return 0, errors.New("Size must be a postive int")
+	}
+
+	totalItems := lru.Len()
+	removeCount := totalItems - newSize
+
+	if removeCount < 0 {
+
+		lru.lock.Lock()
+
+		for key, value := range lru.oldCache {
+			lru.newCache[key] = value
+		}
+
+		lru.oldCache = make(map[K]V)
+		lru.size = totalItems
+		lru.maxSize = newSize
+		lru.lock.Unlock()
+
+		return 0, nil
+
+	} else {
+
+		allPairs := lru.all()
+
+		lru.lock.Lock()
+
+		lru.oldCache = make(map[K]V)
+		lru.newCache = make(map[K]V)
+		lru.size = 0
+		lru.maxSize = newSize
+
+		var i = 0
+
+		for i < removeCount {
+			if lru.onEvictedCB != nil {
+				lru.onEvictedCB(allPairs[i].key, allPairs[i].value)
+			}
+			i++
+		}
+
+		for i < len(allPairs) {
+			lru.oldCache[allPairs[i].key] = allPairs[i].value
+			i++
+		}
+
+		lru.lock.Unlock()
+
+		return removeCount, nil
+
+	}
+
+}
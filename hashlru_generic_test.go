@@ -0,0 +1,141 @@
+package hlru
+
+import (
+	"testing"
+)
+
+func Test_Cache(t *testing.T) {
+
+	lru, err := NewCache[int, int](100)
+
+	if err != nil {
+		t.Fatalf("Error in creating Cache: %v", err)
+	}
+
+	for i := 0; i < 150; i++ {
+		lru.Set(i, i)
+	}
+
+	if lru.Len() != 100 {
+		t.Fatalf("Error in Cache length: %v", lru.Len())
+	}
+
+	lru.Clear()
+
+	if lru.Len() != 0 {
+		t.Fatalf("Error in Cache Clear(): %v", lru.Len())
+	}
+
+	lru.Set(1, 1)
+	lru.Set(2, 1)
+
+	keys := lru.Keys()
+
+	for i := 0; i < len(keys); i++ {
+		got, ok := lru.Peek(keys[i])
+		if !ok {
+			t.Fatalf("Error: %v", keys[i])
+		}
+		if got2, ok := lru.Get(keys[i]); !ok || got2 != got {
+			t.Fatalf("Error: Get and Peek disagree on %v: %v vs %v", keys[i], got2, got)
+		}
+	}
+
+	if len(keys) != lru.Len() {
+		t.Fatalf("Error: %v", keys)
+	}
+
+}
+
+func Test_Cache_onEvict(t *testing.T) {
+
+	evicted := 0
+	onEvict := func(key, value int) {
+		if key != value {
+			t.Fatalf("Evict values not equal (%v!=%v)", key, value)
+		}
+		evicted++
+	}
+
+	lru, err := NewCacheWithEvict[int, int](100, onEvict)
+
+	if err != nil {
+		t.Fatalf("Error in creating Cache: %v", err)
+	}
+
+	for i := 0; i < 150; i++ {
+		lru.Set(i, i)
+	}
+
+	if lru.Len() != 100 {
+		t.Fatalf("Error in Cache length: %v", lru.Len())
+	}
+
+	keys := lru.Keys()
+
+	for i := 0; i < len(keys); i++ {
+		if lru.Has(keys[i]) != true {
+			t.Fatalf("Error in Has() Keys()")
+		}
+	}
+
+	if evicted != 0 {
+		t.Fatalf("Error in evict callback: %v", evicted)
+	}
+
+}
+
+func Test_Cache_Remove_Resize(t *testing.T) {
+
+	lru, _ := NewCache[int, int](2)
+
+	lru.Set(1, 1)
+	lru.Set(2, 2)
+
+	ok := lru.Remove(2)
+
+	if !ok {
+		t.Fatalf("Error in Remove()")
+	}
+
+	if lru.Has(2) != false {
+		t.Fatalf("Error in Remove()")
+	}
+
+	lru.Set(3, 3)
+	lru.Set(2, 2)
+
+	if lru.Has(2) == false {
+		t.Fatalf("Error in Has()")
+	}
+
+	if lru.Has(1) != false {
+		t.Fatalf("Error in Remove()")
+	}
+
+	lru.Clear()
+
+	lru.Set(1, 1)
+	lru.Set(2, 2)
+
+	var evicted, _ = lru.Resize(1)
+
+	if evicted != 1 {
+		t.Fatalf("Error in Down Sizing")
+	}
+
+	evicted, _ = lru.Resize(2)
+
+	if evicted != 0 {
+		t.Fatalf("Error in Down Sizing")
+	}
+
+	lru.Set(3, 3)
+	lru.Set(4, 4)
+	lru.Set(5, 5)
+
+	if lru.Len() != 2 {
+		t.Fatalf("Error in Cache length: %v", lru.Len())
+	}
+
+}
@@ -0,0 +1,169 @@
+package hlru
+
+import "container/list"
+
+/*
+simpleLRU[K, V] is a small, unexported doubly-linked-list based LRU used
+as a building block by cache variants (TwoQueue, ARC) that need to
+juggle more than one internally-ordered list of entries. It does not
+lock - callers are expected to hold whatever lock guards the outer
+cache.
+*/
+
+type simpleEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+type simpleLRU[K comparable, V any] struct {
+	size      int
+	evictList *list.List
+	items     map[K]*list.Element
+}
+
+func newSimpleLRU[K comparable, V any](size int) *simpleLRU[K, V] {
+
+	return &simpleLRU[K, V]{
+		size:      size,
+		evictList: list.New(),
+		items:     make(map[K]*list.Element),
+	}
+
+}
+
+// add inserts or updates key/value at the front, evicting the oldest
+// entry if the list is now over its size limit.
+func (c *simpleLRU[K, V]) add(key K, value V) (evictedKey K, evictedValue V, evicted bool) {
+
+	if ent, ok := c.items[key]; ok {
+		c.evictList.MoveToFront(ent)
+		ent.Value.(*simpleEntry[K, V]).value = value
+		return
+	}
+
+	ent := &simpleEntry[K, V]{key: key, value: value}
+	element := c.evictList.PushFront(ent)
+	c.items[key] = element
+
+	if c.evictList.Len() > c.size {
+		return c.removeOldest()
+	}
+
+	return
+
+}
+
+func (c *simpleLRU[K, V]) get(key K) (V, bool) {
+
+	if ent, ok := c.items[key]; ok {
+		c.evictList.MoveToFront(ent)
+		return ent.Value.(*simpleEntry[K, V]).value, true
+	}
+
+	var zero V
+	return zero, false
+
+}
+
+func (c *simpleLRU[K, V]) peek(key K) (V, bool) {
+
+	if ent, ok := c.items[key]; ok {
+		return ent.Value.(*simpleEntry[K, V]).value, true
+	}
+
+	var zero V
+	return zero, false
+
+}
+
+func (c *simpleLRU[K, V]) contains(key K) bool {
+
+	_, ok := c.items[key]
+	return ok
+
+}
+
+func (c *simpleLRU[K, V]) remove(key K) (V, bool) {
+
+	if ent, ok := c.items[key]; ok {
+		value := ent.Value.(*simpleEntry[K, V]).value
+		c.removeElement(ent)
+		return value, true
+	}
+
+	var zero V
+	return zero, false
+
+}
+
+func (c *simpleLRU[K, V]) removeOldest() (key K, value V, ok bool) {
+
+	ent := c.evictList.Back()
+	if ent == nil {
+		return
+	}
+
+	kv := ent.Value.(*simpleEntry[K, V])
+	c.removeElement(ent)
+	return kv.key, kv.value, true
+
+}
+
+func (c *simpleLRU[K, V]) removeElement(e *list.Element) {
+
+	c.evictList.Remove(e)
+	kv := e.Value.(*simpleEntry[K, V])
+	delete(c.items, kv.key)
+
+}
+
+func (c *simpleLRU[K, V]) keys() []K {
+
+	keys := make([]K, 0, c.evictList.Len())
+	for e := c.evictList.Back(); e != nil; e = e.Prev() {
+		keys = append(keys, e.Value.(*simpleEntry[K, V]).key)
+	}
+
+	return keys
+
+}
+
+func (c *simpleLRU[K, V]) vals() []V {
+
+	vals := make([]V, 0, c.evictList.Len())
+	for e := c.evictList.Back(); e != nil; e = e.Prev() {
+		vals = append(vals, e.Value.(*simpleEntry[K, V]).value)
+	}
+
+	return vals
+
+}
+
+func (c *simpleLRU[K, V]) len() int {
+
+	return c.evictList.Len()
+
+}
+
+func (c *simpleLRU[K, V]) purge() {
+
+	c.evictList.Init()
+	c.items = make(map[K]*list.Element)
+
+}
+
+func (c *simpleLRU[K, V]) resize(size int) (evicted int) {
+
+	diff := c.evictList.Len() - size
+	if diff < 0 {
+		diff = 0
+	}
+
+	for i := 0; i < diff; i++ {
+		c.removeOldest()
+	}
+
+	c.size = size
+	return diff
+
+}
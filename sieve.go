@@ -0,0 +1,302 @@
+package hlru
+
+import (
+	"errors"
+	"sync"
+)
+
+/*
+Sieve[K, V] implements the SIEVE eviction algorithm, which tends to beat
+plain LRU on Zipfian / web-traffic traces at a similar cost.
+
+Entries live on a single doubly-linked list (head = most recently
+inserted, tail = oldest) plus a map from key to node. Each node carries
+one "visited" bit. Get only sets that bit - it never moves the node -
+so reads stay cheap. Eviction is driven by a "hand" pointer that walks
+backward from its current position: a visited node is un-marked and
+skipped, an unvisited node is evicted and the hand moves on to the node
+before it. The hand wraps around to the tail once it runs past the
+head.
+*/
+
+type sieveNode[K comparable, V any] struct {
+	key        K
+	value      V
+	visited    bool
+	prev, next *sieveNode[K, V]
+}
+
+type Sieve[K comparable, V any] struct {
+	maxSize     int
+	size        int
+	items       map[K]*sieveNode[K, V]
+	head, tail  *sieveNode[K, V]
+	hand        *sieveNode[K, V]
+	onEvictedCB func(key K, value V)
+	lock        sync.RWMutex
+}
+
+// Returns a new Sieve cache instance
+func NewSieve[K comparable, V any](maxSize int) (*Sieve[K, V], error) {
+
+	return NewSieveWithEvict[K, V](maxSize, nil)
+
+}
+
+func NewSieveWithEvict[K comparable, V any](maxSize int, onEvict func(key K, value V)) (*Sieve[K, V], error) {
+
+	if maxSize <= 0 {
+		return nil, errors.New("Size must be a postive int")
+	}
+
+	lru := &Sieve[K, V]{
+		maxSize:     maxSize,
+		size:        0,
+		onEvictedCB: onEvict,
+		items:       make(map[K]*sieveNode[K, V]),
+	}
+
+	return lru, nil
+
+}
+
+func (s *Sieve[K, V]) pushFront(node *sieveNode[K, V]) {
+
+	node.prev = nil
+	node.next = s.head
+
+	if s.head != nil {
+		s.head.prev = node
+	}
+
+	s.head = node
+
+	if s.tail == nil {
+		s.tail = node
+	}
+
+}
+
+func (s *Sieve[K, V]) unlink(node *sieveNode[K, V]) {
+
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		s.head = node.next
+	}
+
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		s.tail = node.prev
+	}
+
+	if s.hand == node {
+		s.hand = node.prev
+	}
+
+}
+
+// evict runs the SIEVE hand and removes one node. Must be called with
+// s.lock held and with at least one item in the cache.
+func (s *Sieve[K, V]) evict() {
+
+	if s.hand == nil {
+		s.hand = s.tail
+	}
+
+	for s.hand.visited {
+		s.hand.visited = false
+		s.hand = s.hand.prev
+		if s.hand == nil {
+			s.hand = s.tail
+		}
+	}
+
+	victim := s.hand
+	s.hand = victim.prev
+
+	delete(s.items, victim.key)
+	s.unlink(victim)
+	s.size--
+
+	if s.onEvictedCB != nil {
+		s.onEvictedCB(victim.key, victim.value)
+	}
+
+}
+
+// Set a value and update the cache
+func (s *Sieve[K, V]) Set(key K, value V) {
+
+	s.lock.Lock()
+
+	if node, found := s.items[key]; found {
+		node.value = value
+		node.visited = true
+		s.lock.Unlock()
+		return
+	}
+
+	if s.size >= s.maxSize {
+		s.evict()
+	}
+
+	node := &sieveNode[K, V]{key: key, value: value}
+	s.pushFront(node)
+	s.items[key] = node
+	s.size++
+
+	s.lock.Unlock()
+
+}
+
+// Get a value, marking it as visited
+func (s *Sieve[K, V]) Get(key K) (V, bool) {
+
+	s.lock.Lock()
+
+	if node, found := s.items[key]; found {
+		node.visited = true
+		value := node.value
+		s.lock.Unlock()
+		return value, true
+	}
+
+	s.lock.Unlock()
+	var zero V
+	return zero, false
+
+}
+
+// Peek the value of a key without marking it as visited
+func (s *Sieve[K, V]) Peek(key K) (V, bool) {
+
+	s.lock.RLock()
+
+	if node, found := s.items[key]; found {
+		s.lock.RUnlock()
+		return node.value, true
+	}
+
+	s.lock.RUnlock()
+	var zero V
+	return zero, false
+
+}
+
+// Checks if a key exists in cache
+func (s *Sieve[K, V]) Has(key K) bool {
+
+	s.lock.RLock()
+	_, found := s.items[key]
+	s.lock.RUnlock()
+
+	return found
+
+}
+
+// Removes a key from the cache
+func (s *Sieve[K, V]) Remove(key K) bool {
+
+	s.lock.Lock()
+
+	node, found := s.items[key]
+	if !found {
+		s.lock.Unlock()
+		return false
+	}
+
+	delete(s.items, key)
+	s.unlink(node)
+	s.size--
+
+	if s.onEvictedCB != nil {
+		s.onEvictedCB(node.key, node.value)
+	}
+
+	s.lock.Unlock()
+	return true
+
+}
+
+// Returns the number of items in the cache.
+func (s *Sieve[K, V]) Len() int {
+
+	s.lock.RLock()
+	n := s.size
+	s.lock.RUnlock()
+
+	return n
+
+}
+
+// Clears all entries.
+func (s *Sieve[K, V]) Clear() {
+
+	s.lock.Lock()
+
+	if s.onEvictedCB != nil {
+		for node := s.head; node != nil; node = node.next {
+			s.onEvictedCB(node.key, node.value)
+		}
+	}
+
+	s.items = make(map[K]*sieveNode[K, V])
+	s.head, s.tail, s.hand = nil, nil, nil
+	s.size = 0
+
+	s.lock.Unlock()
+
+}
+
+func (s *Sieve[K, V]) Keys() []K {
+
+	s.lock.RLock()
+
+	keys := make([]K, 0, s.size)
+	for node := s.head; node != nil; node = node.next {
+		keys = append(keys, node.key)
+	}
+
+	s.lock.RUnlock()
+	return keys
+
+}
+
+func (s *Sieve[K, V]) Vals() []V {
+
+	s.lock.RLock()
+
+	vals := make([]V, 0, s.size)
+	for node := s.head; node != nil; node = node.next {
+		vals = append(vals, node.value)
+	}
+
+	s.lock.RUnlock()
+	return vals
+
+}
+
+// Resizes cache, returning number of items deleted
+func (s *Sieve[K, V]) Resize(newSize int) (int, error) {
+
+	if newSize <= 0 {
+		return 0, errors.New("Size must be a postive int")
+	}
+
+	s.lock.Lock()
+
+	s.maxSize = newSize
+
+	removed := 0
+	for s.size > s.maxSize {
+		s.evict()
+		removed++
+	}
+
+	s.lock.Unlock()
+
+	return removed, nil
+
+}
@@ -0,0 +1,109 @@
+package hlru
+
+import (
+	"testing"
+)
+
+func Test_Sieve(t *testing.T) {
+
+	s, err := NewSieve[int, int](100)
+
+	if err != nil {
+		t.Fatalf("Error in creating Sieve: %v", err)
+	}
+
+	for i := 0; i < 150; i++ {
+		s.Set(i, i)
+	}
+
+	if s.Len() != 100 {
+		t.Fatalf("Error in Sieve length: %v", s.Len())
+	}
+
+	s.Clear()
+
+	if s.Len() != 0 {
+		t.Fatalf("Error in Sieve Clear(): %v", s.Len())
+	}
+
+	s.Set(1, 1)
+	s.Set(2, 1)
+
+	keys := s.Keys()
+
+	for i := 0; i < len(keys); i++ {
+		// Get marks the node visited; Peek must still find it afterwards,
+		// confirming Get never unlinks or moves the node.
+		_, ok := s.Get(keys[i])
+		if !ok {
+			t.Fatalf("Error: %v", keys[i])
+		}
+		if _, ok := s.Peek(keys[i]); !ok {
+			t.Fatalf("Error: %v missing after Get", keys[i])
+		}
+	}
+
+	if len(keys) != s.Len() {
+		t.Fatalf("Error: %v", keys)
+	}
+
+}
+
+func Test_Sieve_KeepsVisited(t *testing.T) {
+
+	s, _ := NewSieve[int, int](3)
+
+	s.Set(1, 1)
+	s.Set(2, 2)
+	s.Set(3, 3)
+
+	// Touch 1 and 2 so they're marked visited; 3 is the only unvisited
+	// entry and should be the one SIEVE evicts.
+	s.Get(1)
+	s.Get(2)
+
+	s.Set(4, 4)
+
+	if s.Has(3) {
+		t.Fatalf("Error: expected unvisited key 3 to be evicted")
+	}
+
+	if !s.Has(1) || !s.Has(2) || !s.Has(4) {
+		t.Fatalf("Error: expected visited keys to survive eviction")
+	}
+
+}
+
+func Test_Sieve_Remove_Resize(t *testing.T) {
+
+	s, _ := NewSieve[int, int](2)
+
+	s.Set(1, 1)
+	s.Set(2, 2)
+
+	ok := s.Remove(2)
+
+	if !ok {
+		t.Fatalf("Error in Remove()")
+	}
+
+	if s.Has(2) != false {
+		t.Fatalf("Error in Remove()")
+	}
+
+	s.Clear()
+
+	s.Set(1, 1)
+	s.Set(2, 2)
+
+	evicted, _ := s.Resize(1)
+
+	if evicted != 1 {
+		t.Fatalf("Error in Down Sizing")
+	}
+
+	if s.Len() != 1 {
+		t.Fatalf("Error in Sieve length: %v", s.Len())
+	}
+
+}
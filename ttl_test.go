@@ -0,0 +1,73 @@
+package hlru
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_HLRU_TTL(t *testing.T) {
+
+	lru, err := NewHLRU(10)
+
+	if err != nil {
+		t.Fatalf("Error in creating LRU: %v", err)
+	}
+
+	lru.SetWithTTL("key", "value", 10*time.Millisecond)
+
+	if _, ok := lru.Get("key"); !ok {
+		t.Fatalf("Error: expected key to be present before expiration")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := lru.Get("key"); ok {
+		t.Fatalf("Error: expected key to be absent after expiration")
+	}
+
+}
+
+func Test_HLRU_GetWithExpiration(t *testing.T) {
+
+	lru, _ := NewHLRU(10)
+
+	lru.SetWithTTL("key", "value", time.Hour)
+
+	value, expiresAt, ok := lru.GetWithExpiration("key")
+
+	if !ok || value != "value" {
+		t.Fatalf("Error: expected key to be present")
+	}
+
+	if expiresAt <= time.Now().UnixNano() {
+		t.Fatalf("Error: expected a future expiration deadline")
+	}
+
+}
+
+func Test_HLRU_Janitor(t *testing.T) {
+
+	lru, err := NewHLRUWithTTL(10, 10*time.Millisecond)
+
+	if err != nil {
+		t.Fatalf("Error in creating LRU: %v", err)
+	}
+	defer lru.Close()
+
+	evicted := make(chan interface{}, 1)
+	lru.onEvictedCB = func(key, value interface{}) {
+		evicted <- key
+	}
+
+	lru.Set("key", "value")
+
+	select {
+	case key := <-evicted:
+		if key != "key" {
+			t.Fatalf("Error: unexpected evicted key: %v", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Error: janitor did not sweep expired entry in time")
+	}
+
+}
@@ -0,0 +1,330 @@
+package hlru
+
+import (
+	"errors"
+	"sync"
+)
+
+/*
+TwoQueue[K, V] implements the 2Q cache, following the design used in
+hashicorp/golang-lru's 2q.go. It partitions the cache into three
+internal LRU lists:
+
+  - recent (A1in): entries seen once, default 25% of capacity
+  - frequent (Am): entries seen at least twice, the remainder
+  - recentEvict (A1out): a ghost list of keys recently evicted out of
+    recent, holding no values, default 50% of capacity
+
+A brand-new key goes into recent. A key found in recentEvict is
+promoted straight into frequent - that's the signal that it was
+requested again shortly after falling out of recent. A key already in
+frequent is updated in place; a key already in recent is moved into
+frequent by Set. Get on frequent refreshes its recency; Get on recent
+does not promote it, only a subsequent Set does.
+*/
+
+const (
+	// Default2QRecentRatio is the default ratio of the total size
+	// reserved for the recent (A1in) list in New2Q.
+	Default2QRecentRatio = 0.25
+
+	// Default2QGhostRatio is the default ratio of the total size used
+	// for the ghost (A1out) list in New2Q.
+	Default2QGhostRatio = 0.50
+)
+
+type TwoQueue[K comparable, V any] struct {
+	size        int
+	recentSize  int
+	recentRatio float64
+	ghostRatio  float64
+
+	recent      *simpleLRU[K, V]
+	frequent    *simpleLRU[K, V]
+	recentEvict *simpleLRU[K, struct{}]
+
+	onEvictedCB func(key K, value V)
+	lock        sync.RWMutex
+}
+
+// New2Q creates a new 2Q cache with the default recent/ghost ratios.
+func New2Q[K comparable, V any](size int) (*TwoQueue[K, V], error) {
+
+	return New2QParams[K, V](size, Default2QRecentRatio, Default2QGhostRatio)
+
+}
+
+func New2QWithEvict[K comparable, V any](size int, onEvict func(key K, value V)) (*TwoQueue[K, V], error) {
+
+	return New2QParamsWithEvict[K, V](size, Default2QRecentRatio, Default2QGhostRatio, onEvict)
+
+}
+
+// New2QParams creates a new 2Q cache with custom recent/ghost ratios,
+// each expressed as a fraction of size in [0, 1].
+func New2QParams[K comparable, V any](size int, recentRatio, ghostRatio float64) (*TwoQueue[K, V], error) {
+
+	return New2QParamsWithEvict[K, V](size, recentRatio, ghostRatio, nil)
+
+}
+
+func New2QParamsWithEvict[K comparable, V any](size int, recentRatio, ghostRatio float64, onEvict func(key K, value V)) (*TwoQueue[K, V], error) {
+
+	if size <= 0 {
+		return nil, errors.New("Size must be a postive int")
+	}
+
+	if recentRatio < 0 || recentRatio > 1 {
+		return nil, errors.New("recentRatio must be between 0 and 1")
+	}
+
+	if ghostRatio < 0 || ghostRatio > 1 {
+		return nil, errors.New("ghostRatio must be between 0 and 1")
+	}
+
+	lru := &TwoQueue[K, V]{
+		size:        size,
+		recentSize:  int(float64(size) * recentRatio),
+		recentRatio: recentRatio,
+		ghostRatio:  ghostRatio,
+		onEvictedCB: onEvict,
+		recent:      newSimpleLRU[K, V](size),
+		frequent:    newSimpleLRU[K, V](size),
+		recentEvict: newSimpleLRU[K, struct{}](int(float64(size) * ghostRatio)),
+	}
+
+	return lru, nil
+
+}
+
+// Set a value and update the cache
+func (lru *TwoQueue[K, V]) Set(key K, value V) {
+
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	if lru.frequent.contains(key) {
+		lru.frequent.add(key, value)
+		return
+	}
+
+	if lru.recent.contains(key) {
+		lru.recent.remove(key)
+		lru.frequent.add(key, value)
+		return
+	}
+
+	if lru.recentEvict.contains(key) {
+		lru.ensureSpace(true)
+		lru.recentEvict.remove(key)
+		lru.frequent.add(key, value)
+		return
+	}
+
+	lru.ensureSpace(false)
+	lru.recent.add(key, value)
+
+}
+
+// ensureSpace evicts from recent or frequent if the cache is full.
+// recentEvicting indicates the caller is about to promote a key out of
+// the ghost list; when recent is exactly at its target size, this
+// evicts from frequent instead of recent.
+func (lru *TwoQueue[K, V]) ensureSpace(recentEvicting bool) {
+
+	recentLen := lru.recent.len()
+	freqLen := lru.frequent.len()
+
+	if recentLen+freqLen < lru.size {
+		return
+	}
+
+	if recentLen > 0 && (recentLen > lru.recentSize || (recentLen == lru.recentSize && !recentEvicting)) {
+		key, _, ok := lru.recent.removeOldest()
+		if ok {
+			lru.recentEvict.add(key, struct{}{})
+		}
+		return
+	}
+
+	key, value, ok := lru.frequent.removeOldest()
+	if ok && lru.onEvictedCB != nil {
+		lru.onEvictedCB(key, value)
+	}
+
+}
+
+// Get a value and update the cache
+func (lru *TwoQueue[K, V]) Get(key K) (V, bool) {
+
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	if value, found := lru.frequent.get(key); found {
+		return value, true
+	}
+
+	if value, found := lru.recent.peek(key); found {
+		return value, true
+	}
+
+	var zero V
+	return zero, false
+
+}
+
+// Peek the value of a key without updating the cache
+func (lru *TwoQueue[K, V]) Peek(key K) (V, bool) {
+
+	lru.lock.RLock()
+	defer lru.lock.RUnlock()
+
+	if value, found := lru.frequent.peek(key); found {
+		return value, true
+	}
+
+	if value, found := lru.recent.peek(key); found {
+		return value, true
+	}
+
+	var zero V
+	return zero, false
+
+}
+
+// Checks if a key exists in cache
+func (lru *TwoQueue[K, V]) Has(key K) bool {
+
+	lru.lock.RLock()
+	defer lru.lock.RUnlock()
+
+	return lru.frequent.contains(key) || lru.recent.contains(key)
+
+}
+
+// Removes a key from the cache
+func (lru *TwoQueue[K, V]) Remove(key K) bool {
+
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	if value, found := lru.frequent.remove(key); found {
+		if lru.onEvictedCB != nil {
+			lru.onEvictedCB(key, value)
+		}
+		return true
+	}
+
+	if value, found := lru.recent.remove(key); found {
+		if lru.onEvictedCB != nil {
+			lru.onEvictedCB(key, value)
+		}
+		return true
+	}
+
+	if _, found := lru.recentEvict.remove(key); found {
+		return true
+	}
+
+	return false
+
+}
+
+// Returns the number of items in the cache.
+func (lru *TwoQueue[K, V]) Len() int {
+
+	lru.lock.RLock()
+	defer lru.lock.RUnlock()
+
+	return lru.recent.len() + lru.frequent.len()
+
+}
+
+// Clears all entries.
+func (lru *TwoQueue[K, V]) Clear() {
+
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	if lru.onEvictedCB != nil {
+		for _, key := range lru.recent.keys() {
+			value, _ := lru.recent.peek(key)
+			lru.onEvictedCB(key, value)
+		}
+		for _, key := range lru.frequent.keys() {
+			value, _ := lru.frequent.peek(key)
+			lru.onEvictedCB(key, value)
+		}
+	}
+
+	lru.recent.purge()
+	lru.frequent.purge()
+	lru.recentEvict.purge()
+
+}
+
+func (lru *TwoQueue[K, V]) Keys() []K {
+
+	lru.lock.RLock()
+	defer lru.lock.RUnlock()
+
+	keys := make([]K, 0, lru.recent.len()+lru.frequent.len())
+	keys = append(keys, lru.recent.keys()...)
+	keys = append(keys, lru.frequent.keys()...)
+
+	return keys
+
+}
+
+func (lru *TwoQueue[K, V]) Vals() []V {
+
+	lru.lock.RLock()
+	defer lru.lock.RUnlock()
+
+	vals := make([]V, 0, lru.recent.len()+lru.frequent.len())
+	vals = append(vals, lru.recent.vals()...)
+	vals = append(vals, lru.frequent.vals()...)
+
+	return vals
+
+}
+
+// Resizes cache, returning number of items deleted
+func (lru *TwoQueue[K, V]) Resize(newSize int) (int, error) {
+
+	if newSize <= 0 {
+		return 0, errors.New("Size must be a postive int")
+	}
+
+	lru.lock.Lock()
+	defer lru.lock.Unlock()
+
+	lru.size = newSize
+	lru.recentSize = int(float64(newSize) * lru.recentRatio)
+
+	removed := 0
+	for lru.recent.len()+lru.frequent.len() > lru.size {
+		if lru.recent.len() > lru.recentSize {
+			key, _, ok := lru.recent.removeOldest()
+			if ok {
+				lru.recentEvict.add(key, struct{}{})
+				removed++
+			}
+			continue
+		}
+		evictedKey, value, ok := lru.frequent.removeOldest()
+		if ok {
+			if lru.onEvictedCB != nil {
+				lru.onEvictedCB(evictedKey, value)
+			}
+			removed++
+		}
+	}
+
+	lru.recent.resize(newSize)
+	lru.frequent.resize(newSize)
+	lru.recentEvict.resize(int(float64(newSize) * lru.ghostRatio))
+
+	return removed, nil
+
+}
@@ -0,0 +1,147 @@
+package hlru
+
+import (
+	"testing"
+)
+
+func Test_TwoQueue(t *testing.T) {
+
+	lru, err := New2Q[int, int](100)
+
+	if err != nil {
+		t.Fatalf("Error in creating TwoQueue: %v", err)
+	}
+
+	for i := 0; i < 150; i++ {
+		lru.Set(i, i)
+	}
+
+	if lru.Len() != 100 {
+		t.Fatalf("Error in TwoQueue length: %v", lru.Len())
+	}
+
+	lru.Clear()
+
+	if lru.Len() != 0 {
+		t.Fatalf("Error in TwoQueue Clear(): %v", lru.Len())
+	}
+
+	lru.Set(1, 1)
+	lru.Set(2, 1)
+
+	keys := lru.Keys()
+
+	for i := 0; i < len(keys); i++ {
+		// Both keys are still sitting in recent; per Test_TwoQueue_GetDoesNotPromoteRecent
+		// repeated Gets here must be pure reads rather than promoting them into frequent.
+		_, ok := lru.Get(keys[i])
+		if !ok {
+			t.Fatalf("Error: %v", keys[i])
+		}
+		if lru.frequent.contains(keys[i]) {
+			t.Fatalf("Error: %v promoted to frequent by Get", keys[i])
+		}
+	}
+
+	if len(keys) != lru.Len() {
+		t.Fatalf("Error: %v", keys)
+	}
+
+}
+
+func Test_TwoQueue_GhostPromotion(t *testing.T) {
+
+	lru, _ := New2QParams[int, int](4, 0.5, 0.5)
+
+	lru.Set(1, 1)
+	lru.Set(2, 2)
+	lru.Set(3, 3)
+	lru.Set(4, 4)
+	lru.Set(5, 5) // should push key 1 out of recent, into the ghost list
+
+	lru.Set(1, 1) // hit in the ghost list: should be promoted into frequent
+
+	if !lru.Has(1) {
+		t.Fatalf("Error: expected key 1 to be promoted back into the cache")
+	}
+
+}
+
+func Test_TwoQueue_GetDoesNotPromoteRecent(t *testing.T) {
+
+	lru, _ := New2QParams[int, int](4, 0.5, 0.5)
+
+	lru.Set(1, 1)
+	lru.Set(2, 2)
+
+	for i := 0; i < 10; i++ {
+		lru.Get(1)
+	}
+
+	lru.Set(3, 3)
+	lru.Set(4, 4)
+	lru.Set(5, 5)
+	lru.Set(6, 6)
+	lru.Set(7, 7)
+
+	if lru.Has(1) {
+		t.Fatalf("Error: key 1 should have been evicted from recent, Get must not promote it")
+	}
+
+}
+
+func Test_TwoQueue_Remove_Resize(t *testing.T) {
+
+	lru, _ := New2Q[int, int](2)
+
+	lru.Set(1, 1)
+	lru.Set(2, 2)
+
+	ok := lru.Remove(2)
+
+	if !ok {
+		t.Fatalf("Error in Remove()")
+	}
+
+	if lru.Has(2) != false {
+		t.Fatalf("Error in Remove()")
+	}
+
+	lru.Clear()
+
+	lru.Set(1, 1)
+	lru.Set(2, 2)
+
+	evicted, _ := lru.Resize(1)
+
+	if evicted != 1 {
+		t.Fatalf("Error in Down Sizing")
+	}
+
+	if lru.Len() != 1 {
+		t.Fatalf("Error in TwoQueue length: %v", lru.Len())
+	}
+
+}
+
+func Test_TwoQueue_Resize_Grow(t *testing.T) {
+
+	lru, _ := New2Q[int, int](4)
+
+	for i := 0; i < 4; i++ {
+		lru.Set(i, i)
+	}
+
+	if _, err := lru.Resize(100); err != nil {
+		t.Fatalf("Error in Up Sizing: %v", err)
+	}
+
+	for i := 4; i < 100; i++ {
+		lru.Set(i, i)
+	}
+
+	if lru.Len() != 100 {
+		t.Fatalf("Error in TwoQueue length after growing: %v", lru.Len())
+	}
+
+}